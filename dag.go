@@ -1,7 +1,8 @@
 package golang_dag
 
 import (
-	"container/list"
+	"container/heap"
+	"encoding/json"
 	"errors"
 )
 
@@ -12,23 +13,23 @@ var (
 	ErrVertexNotExists = errors.New("dag: vertex does not exist")
 )
 
-type vertex struct {
+type Vertex[T any] struct {
 	Id       string
-	Value    interface{}
-	Parents  []*vertex
-	Children []*vertex
+	Value    T
+	Parents  []*Vertex[T]
+	Children []*Vertex[T]
 }
 
-func newVertex(id string, value interface{}) *vertex {
-	return &vertex{
+func newVertex[T any](id string, value T) *Vertex[T] {
+	return &Vertex[T]{
 		Id:       id,
 		Value:    value,
-		Parents:  make([]*vertex, 0),
-		Children: make([]*vertex, 0),
+		Parents:  make([]*Vertex[T], 0),
+		Children: make([]*Vertex[T], 0),
 	}
 }
 
-func (v *vertex) removeChild(ChildId string) {
+func (v *Vertex[T]) removeChild(ChildId string) {
 	for i := len(v.Children) - 1; i >= 0; i-- {
 		if ChildId == v.Children[i].Id {
 			copy(v.Children[i:], v.Children[i+1:])
@@ -38,7 +39,7 @@ func (v *vertex) removeChild(ChildId string) {
 	}
 }
 
-func (v *vertex) removeParent(parentId string) {
+func (v *Vertex[T]) removeParent(parentId string) {
 	for i := len(v.Parents) - 1; i >= 0; i-- {
 		if parentId == v.Parents[i].Id {
 			copy(v.Parents[i:], v.Parents[i+1:])
@@ -48,7 +49,7 @@ func (v *vertex) removeParent(parentId string) {
 	}
 }
 
-func (v *vertex) isEqual(v2 *vertex) bool {
+func (v *Vertex[T]) isEqual(v2 *Vertex[T]) bool {
 	if v.Id != v2.Id {
 		return false
 	}
@@ -71,19 +72,42 @@ func (v *vertex) isEqual(v2 *vertex) bool {
 	return true
 }
 
-// A directed acyclic graph implementation
+// A directed acyclic graph implementation, generic over its vertex value
+// type.
 // Not thread safe, caller is responsible to ensure mutex
-type DAG struct {
-	Vertexes map[string]*vertex
+type Graph[T any] struct {
+	Vertexes map[string]*Vertex[T]
+
+	// EncodeValue, if set, is used by MarshalJSON to JSON-encode a vertex
+	// Value when it does not already support encoding/json on its own (e.g.
+	// it is not a plain JSON-compatible type and does not implement
+	// json.Marshaler).
+	EncodeValue func(value T) (json.RawMessage, error)
+	// DecodeValue, if set, is used by UnmarshalJSON to decode a vertex Value
+	// out of its raw JSON representation. If unset, values decode directly
+	// into T the same way encoding/json would.
+	DecodeValue func(raw json.RawMessage) (T, error)
 }
 
-func NewDAG() *DAG {
-	return &DAG{
-		Vertexes: make(map[string]*vertex),
+func NewGraph[T any]() *Graph[T] {
+	return &Graph[T]{
+		Vertexes: make(map[string]*Vertex[T]),
 	}
 }
 
-func (dag *DAG) AddVertex(vertexId string, value interface{}) error {
+// DAG is a thin alias over Graph[interface{}], kept so existing callers
+// that store untyped vertex values keep compiling unchanged.
+type DAG = Graph[interface{}]
+
+// vertex is a thin alias over Vertex[interface{}], kept so DAG's existing
+// method signatures keep compiling unchanged.
+type vertex = Vertex[interface{}]
+
+func NewDAG() *DAG {
+	return NewGraph[interface{}]()
+}
+
+func (dag *Graph[T]) AddVertex(vertexId string, value T) error {
 	if _, ok := dag.Vertexes[vertexId]; ok {
 		return ErrVertexExists
 	}
@@ -92,7 +116,7 @@ func (dag *DAG) AddVertex(vertexId string, value interface{}) error {
 	return nil
 }
 
-func (dag *DAG) RemoveVertex(vertexId string) {
+func (dag *Graph[T]) RemoveVertex(vertexId string) {
 	vertex, ok := dag.Vertexes[vertexId]
 	if !ok {
 		return
@@ -107,11 +131,11 @@ func (dag *DAG) RemoveVertex(vertexId string) {
 	delete(dag.Vertexes, vertexId)
 }
 
-func (dag *DAG) AddEdge(fromVertexId, toVertexId string) error {
+func (dag *Graph[T]) AddEdge(fromVertexId, toVertexId string) error {
 	if fromVertexId == toVertexId {
 		return ErrCycle
 	}
-	var from, to *vertex
+	var from, to *Vertex[T]
 	var ok bool
 
 	if from, ok = dag.Vertexes[fromVertexId]; !ok {
@@ -137,8 +161,8 @@ func (dag *DAG) AddEdge(fromVertexId, toVertexId string) error {
 	return nil
 }
 
-func (dag *DAG) RemoveEdge(fromVertexId, toVertexId string) error {
-	var from, to *vertex
+func (dag *Graph[T]) RemoveEdge(fromVertexId, toVertexId string) error {
+	var from, to *Vertex[T]
 	var ok bool
 
 	if from, ok = dag.Vertexes[fromVertexId]; !ok {
@@ -154,8 +178,8 @@ func (dag *DAG) RemoveEdge(fromVertexId, toVertexId string) error {
 	return nil
 }
 
-func (dag *DAG) EdgeExists(fromVertexId, toVertexId string) (bool, error) {
-	var from, to *vertex
+func (dag *Graph[T]) EdgeExists(fromVertexId, toVertexId string) (bool, error) {
+	var from, to *Vertex[T]
 	var ok bool
 
 	if from, ok = dag.Vertexes[fromVertexId]; !ok {
@@ -180,7 +204,7 @@ func (dag *DAG) EdgeExists(fromVertexId, toVertexId string) (bool, error) {
 	return false, nil
 }
 
-func (dag *DAG) GetVertex(id string) *vertex {
+func (dag *Graph[T]) GetVertex(id string) *Vertex[T] {
 	if v, ok := dag.Vertexes[id]; ok {
 		return v
 	}
@@ -188,13 +212,13 @@ func (dag *DAG) GetVertex(id string) *vertex {
 	return nil
 }
 
-func (dag *DAG) DepthFirstSearch(fromVertexId, toVertexId string) bool {
+func (dag *Graph[T]) DepthFirstSearch(fromVertexId, toVertexId string) bool {
 	found := map[string]bool{}
 	dag.dfs(found, fromVertexId)
 	return found[toVertexId]
 }
 
-func (dag *DAG) dfs(found map[string]bool, vertexId string) {
+func (dag *Graph[T]) dfs(found map[string]bool, vertexId string) {
 	vertex, ok := dag.Vertexes[vertexId]
 	if !ok {
 		return
@@ -207,7 +231,7 @@ func (dag *DAG) dfs(found map[string]bool, vertexId string) {
 	}
 }
 
-func (dag *DAG) IsEqual(dag2 *DAG) bool {
+func (dag *Graph[T]) IsEqual(dag2 *Graph[T]) bool {
 	if len(dag.Vertexes) != len(dag2.Vertexes) {
 		return false
 	}
@@ -224,10 +248,10 @@ func (dag *DAG) IsEqual(dag2 *DAG) bool {
 }
 
 // shallow Copy
-func (dag *DAG) Copy() *DAG {
-	new := NewDAG()
+func (dag *Graph[T]) Copy() *Graph[T] {
+	new := NewGraph[T]()
 	for _, v := range dag.Vertexes {
-		new.Vertexes[v.Id] = &vertex{
+		new.Vertexes[v.Id] = &Vertex[T]{
 			Id:    v.Id,
 			Value: v.Value,
 		}
@@ -240,88 +264,89 @@ func (dag *DAG) Copy() *DAG {
 	return new
 }
 
-func (dag *DAG) TopologicalSort() []*vertex {
-	copy := dag.Copy()
-
-	sort := []*vertex{}
-	for {
-		for _, v := range copy.Vertexes {
-			if len(v.Parents) != 0 {
-				continue
-			}
-			for _, child := range v.Children {
-				child.removeParent(v.Id)
-			}
-			delete(copy.Vertexes, v.Id)
-			sort = append(sort, v)
-		}
-		if len(copy.Vertexes) == 0 {
-			break
+// TopologicalSort orders the graph's vertexes via Kahn's algorithm: track
+// each vertex's remaining parent count in a local map (the graph itself is
+// never copied or mutated) and repeatedly peel off a vertex whose count has
+// reached zero. Runs in O(V+E). Unlike the previous Copy()-based
+// implementation, the returned vertexes are the graph's own, not copies —
+// callers must not mutate their Parents/Children.
+func (dag *Graph[T]) TopologicalSort() []*Vertex[T] {
+	remainingParents := make(map[string]int, len(dag.Vertexes))
+	ready := make([]*Vertex[T], 0, len(dag.Vertexes))
+	for id, v := range dag.Vertexes {
+		remainingParents[id] = len(v.Parents)
+		if remainingParents[id] == 0 {
+			ready = append(ready, v)
 		}
 	}
 
-	return sort
-}
-
-func (dag *DAG) TopologicalSortStable() []*vertex {
-	copy := dag.Copy()
-	noParentsVertexes := newSortedVertexes()
-	length := len(copy.Vertexes)
-	sort := make([]*vertex, 0, length)
-	if length == 0 {
-		return sort
-	}
+	sort := make([]*Vertex[T], 0, len(dag.Vertexes))
+	for len(ready) > 0 {
+		v := ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+		sort = append(sort, v)
 
-	for {
-		for _, v := range copy.Vertexes {
-			if len(v.Parents) != 0 {
-				continue
+		for _, child := range v.Children {
+			remainingParents[child.Id]--
+			if remainingParents[child.Id] == 0 {
+				ready = append(ready, child)
 			}
-			noParentsVertexes.add(v)
-			delete(copy.Vertexes, v.Id)
-		}
-		firstNoParentsVertex := noParentsVertexes.popFront()
-		sort = append(sort, firstNoParentsVertex)
-		if len(sort) == length {
-			break
-		}
-		for _, child := range firstNoParentsVertex.Children {
-			child.removeParent(firstNoParentsVertex.Id)
 		}
 	}
 
 	return sort
 }
 
-type sortedVertexes struct {
-	*list.List
-}
+// TopologicalSortStable is TopologicalSort with a deterministic tie-break:
+// among vertexes that are simultaneously ready, the one with the
+// lexicographically smallest id is emitted first. The ready set is backed
+// by a min-heap keyed on id, giving O((V+E) log V) instead of repeatedly
+// scanning for parentless vertexes.
+func (dag *Graph[T]) TopologicalSortStable() []*Vertex[T] {
+	remainingParents := make(map[string]int, len(dag.Vertexes))
+	ready := make(vertexHeap[T], 0, len(dag.Vertexes))
+	for id, v := range dag.Vertexes {
+		remainingParents[id] = len(v.Parents)
+		if remainingParents[id] == 0 {
+			ready = append(ready, v)
+		}
+	}
+	heap.Init(&ready)
 
-func newSortedVertexes() *sortedVertexes {
-	l := list.New()
-	return &sortedVertexes{l}
-}
+	sort := make([]*Vertex[T], 0, len(dag.Vertexes))
+	for ready.Len() > 0 {
+		v := heap.Pop(&ready).(*Vertex[T])
+		sort = append(sort, v)
 
-func (s *sortedVertexes) add(v *vertex) {
-	for e := s.Front(); e != nil; e = e.Next() {
-		if v.Id < e.Value.(*vertex).Id {
-			s.InsertBefore(v, e)
-			return
+		for _, child := range v.Children {
+			remainingParents[child.Id]--
+			if remainingParents[child.Id] == 0 {
+				heap.Push(&ready, child)
+			}
 		}
 	}
-	s.PushBack(v)
+
+	return sort
 }
 
-func (s *sortedVertexes) popFront() *vertex {
-	e := s.Front()
-	if nil == e {
-		return nil
-	}
-	s.Remove(e)
-	return e.Value.(*vertex)
+// vertexHeap is a container/heap min-heap of vertexes, ordered by Id, used
+// to back the ready set in TopologicalSortStable.
+type vertexHeap[T any] []*Vertex[T]
+
+func (h vertexHeap[T]) Len() int            { return len(h) }
+func (h vertexHeap[T]) Less(i, j int) bool  { return h[i].Id < h[j].Id }
+func (h vertexHeap[T]) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *vertexHeap[T]) Push(x interface{}) { *h = append(*h, x.(*Vertex[T])) }
+
+func (h *vertexHeap[T]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	v := old[n-1]
+	*h = old[:n-1]
+	return v
 }
 
-func (dag *DAG) Print() (str string) {
+func (dag *Graph[T]) Print() (str string) {
 	for _, v := range dag.Vertexes {
 		if len(v.Parents) == 0 {
 			str = str + dag.print(v, "") + "\n"
@@ -330,7 +355,7 @@ func (dag *DAG) Print() (str string) {
 	return str
 }
 
-func (dag *DAG) print(root *vertex, prefix string) string {
+func (dag *Graph[T]) print(root *Vertex[T], prefix string) string {
 	str := prefix + root.Id + "\n"
 	for i, child := range root.Children {
 		// If last iteration, don't add a pipe character
@@ -0,0 +1,175 @@
+package golang_dag
+
+import "sort"
+
+// AutoGroup iteratively finds pairs of vertexes for which cmp returns true
+// and merging them would not introduce a cycle, and collapses each such
+// pair into a single vertex whose value is merge(a, b) and whose parents
+// and children are the union of the originals'. Vertexes are considered in
+// sorted id order so the result is deterministic. It returns the number of
+// groupings performed.
+func (dag *Graph[T]) AutoGroup(cmp func(a, b *Vertex[T]) bool, merge func(a, b *Vertex[T]) T) int {
+	groups := 0
+	for {
+		ids := dag.sortedVertexIds()
+		merged := false
+		for i := 0; i < len(ids) && !merged; i++ {
+			a, ok := dag.Vertexes[ids[i]]
+			if !ok {
+				continue
+			}
+			for j := i + 1; j < len(ids); j++ {
+				b, ok := dag.Vertexes[ids[j]]
+				if !ok {
+					continue
+				}
+				if !cmp(a, b) {
+					continue
+				}
+				if err := dag.mergeVertexes(a, b, merge); err != nil {
+					continue
+				}
+				groups++
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			break
+		}
+	}
+	return groups
+}
+
+// AutoGroupVertices explicitly merges the given vertex ids into a single
+// vertex, in the order given, combining values pairwise with merge the
+// same way AutoGroup merges a matching pair. It returns ErrVertexNotExists
+// if any id is missing, or ErrCycle if merging would introduce one.
+func (dag *Graph[T]) AutoGroupVertices(ids []string, merge func(a, b *Vertex[T]) T) error {
+	if len(ids) < 2 {
+		return nil
+	}
+	currentId := ids[0]
+	if _, ok := dag.Vertexes[currentId]; !ok {
+		return ErrVertexNotExists
+	}
+	for _, id := range ids[1:] {
+		a, ok := dag.Vertexes[currentId]
+		if !ok {
+			return ErrVertexNotExists
+		}
+		b, ok := dag.Vertexes[id]
+		if !ok {
+			return ErrVertexNotExists
+		}
+		if err := dag.mergeVertexes(a, b, merge); err != nil {
+			return err
+		}
+		currentId = mergedVertexId(a.Id, b.Id)
+	}
+	return nil
+}
+
+// GroupValues is a convenience merge function for untyped graphs (DAG /
+// Graph[interface{}]) that combines two vertex values into a
+// []interface{}, growing it if either side is already such a slice. Pass
+// it as the merge argument to AutoGroup or AutoGroupVertices when grouping
+// untyped vertexes.
+func GroupValues(a, b *Vertex[interface{}]) interface{} {
+	out := []interface{}{}
+	for _, value := range []interface{}{a.Value, b.Value} {
+		if vs, ok := value.([]interface{}); ok {
+			out = append(out, vs...)
+		} else {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+func mergedVertexId(a, b string) string {
+	return a + "+" + b
+}
+
+func (dag *Graph[T]) sortedVertexIds() []string {
+	ids := make([]string, 0, len(dag.Vertexes))
+	for id := range dag.Vertexes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// canMerge reports whether merging a and b would keep the graph acyclic. It
+// computes the parent and child sets the merged vertex would end up with
+// and rejects the merge if any resulting child is, or can reach, any
+// resulting parent.
+func (dag *Graph[T]) canMerge(a, b *Vertex[T]) bool {
+	parents := mergedNeighborSet(a.Parents, b.Parents, a.Id, b.Id)
+	children := mergedNeighborSet(a.Children, b.Children, a.Id, b.Id)
+
+	for pId := range parents {
+		for cId, c := range children {
+			if cId == pId {
+				return false
+			}
+			if dag.DepthFirstSearch(c.Id, pId) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func mergedNeighborSet[T any](a, b []*Vertex[T], excludeA, excludeB string) map[string]*Vertex[T] {
+	set := make(map[string]*Vertex[T])
+	for _, v := range a {
+		if v.Id != excludeA && v.Id != excludeB {
+			set[v.Id] = v
+		}
+	}
+	for _, v := range b {
+		if v.Id != excludeA && v.Id != excludeB {
+			set[v.Id] = v
+		}
+	}
+	return set
+}
+
+// mergeVertexes replaces a and b with a single new vertex holding
+// merge(a, b), whose parents and children are the union of a's and b's
+// (excluding a and b themselves). It returns ErrCycle if the merge is not
+// acyclicity-preserving, or ErrVertexExists if the synthetic id a.Id+"+"+b.Id
+// already names a different vertex; in both cases the graph is left
+// unmodified.
+func (dag *Graph[T]) mergeVertexes(a, b *Vertex[T], merge func(a, b *Vertex[T]) T) error {
+	if !dag.canMerge(a, b) {
+		return ErrCycle
+	}
+
+	newId := mergedVertexId(a.Id, b.Id)
+	if existing, ok := dag.Vertexes[newId]; ok && existing != a && existing != b {
+		return ErrVertexExists
+	}
+
+	parents := mergedNeighborSet(a.Parents, b.Parents, a.Id, b.Id)
+	children := mergedNeighborSet(a.Children, b.Children, a.Id, b.Id)
+	value := merge(a, b)
+
+	dag.RemoveVertex(a.Id)
+	dag.RemoveVertex(b.Id)
+	if err := dag.AddVertex(newId, value); err != nil {
+		return err
+	}
+	for pId := range parents {
+		if err := dag.AddEdge(pId, newId); err != nil {
+			return err
+		}
+	}
+	for cId := range children {
+		if err := dag.AddEdge(newId, cId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package golang_dag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDAG_AutoGroup(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("install-x", "install-x")
+	dag.AddVertex("install-y", "install-y")
+	dag.AddVertex("configure", "configure")
+	dag.AddEdge("install-x", "configure")
+	dag.AddEdge("install-y", "configure")
+
+	groups := dag.AutoGroup(
+		func(a, b *vertex) bool {
+			return strings.HasPrefix(a.Id, "install-") && strings.HasPrefix(b.Id, "install-")
+		},
+		func(a, b *vertex) interface{} {
+			return []interface{}{a.Value, b.Value}
+		},
+	)
+	if groups != 1 {
+		t.Fatalf("expected 1 grouping, got %d", groups)
+	}
+	if len(dag.Vertexes) != 2 {
+		t.Fatalf("expected 2 vertexes after grouping, got %d", len(dag.Vertexes))
+	}
+
+	merged := dag.GetVertex("install-x+install-y")
+	if merged == nil {
+		t.Fatalf("expected merged vertex install-x+install-y")
+	}
+	if len(merged.Children) != 1 || merged.Children[0].Id != "configure" {
+		t.Fatalf("expected merged vertex to have configure as its only child, got %v", merged.Children)
+	}
+}
+
+func TestDAG_AutoGroup_RejectsCycle(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("a", "a")
+	dag.AddVertex("b", "b")
+	dag.AddVertex("x", "x")
+	dag.AddEdge("a", "x")
+	dag.AddEdge("x", "b")
+
+	groups := dag.AutoGroup(
+		func(a, b *vertex) bool {
+			return (a.Id == "a" && b.Id == "b") || (a.Id == "b" && b.Id == "a")
+		},
+		func(a, b *vertex) interface{} { return []interface{}{a.Value, b.Value} },
+	)
+	if groups != 0 {
+		t.Fatalf("expected no groupings since a+b would create a cycle through x, got %d", groups)
+	}
+}
+
+func TestDAG_AutoGroupVertices(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", 1)
+	dag.AddVertex("v-2", 2)
+	dag.AddVertex("v-3", 3)
+	dag.AddVertex("v-4", 4)
+	dag.AddEdge("v-1", "v-4")
+	dag.AddEdge("v-2", "v-4")
+	dag.AddEdge("v-3", "v-4")
+
+	if err := dag.AutoGroupVertices([]string{"v-1", "v-2", "v-3"}, GroupValues); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	merged := dag.GetVertex("v-1+v-2+v-3")
+	if merged == nil {
+		t.Fatalf("expected merged vertex v-1+v-2+v-3")
+	}
+	values, ok := merged.Value.([]interface{})
+	if !ok || len(values) != 3 {
+		t.Fatalf("expected merged value to hold 3 original values, got %v", merged.Value)
+	}
+}
+
+func TestDAG_AutoGroup_RejectsIdCollision(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v1", "v1")
+	dag.AddVertex("v2", "v2")
+	dag.AddVertex("v1+v2", "pre-existing")
+
+	groups := dag.AutoGroup(
+		func(a, b *vertex) bool {
+			return (a.Id == "v1" && b.Id == "v2") || (a.Id == "v2" && b.Id == "v1")
+		},
+		func(a, b *vertex) interface{} { return []interface{}{a.Value, b.Value} },
+	)
+	if groups != 0 {
+		t.Fatalf("expected no groupings since v1+v2 already names another vertex, got %d", groups)
+	}
+	if dag.GetVertex("v1") == nil || dag.GetVertex("v2") == nil {
+		t.Fatalf("expected v1 and v2 to survive a rejected merge")
+	}
+	if dag.GetVertex("v1+v2").Value != "pre-existing" {
+		t.Fatalf("expected pre-existing v1+v2 vertex to be untouched")
+	}
+}
+
+func TestDAG_AutoGroupVertices_RejectsIdCollision(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v1", "v1")
+	dag.AddVertex("v2", "v2")
+	dag.AddVertex("v1+v2", "pre-existing")
+
+	if err := dag.AutoGroupVertices([]string{"v1", "v2"}, GroupValues); err != ErrVertexExists {
+		t.Fatalf("expected ErrVertexExists, got %v", err)
+	}
+	if dag.GetVertex("v1") == nil || dag.GetVertex("v2") == nil {
+		t.Fatalf("expected v1 and v2 to survive a rejected merge")
+	}
+}
+
+func TestDAG_AutoGroupVertices_MissingVertex(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", 1)
+
+	if err := dag.AutoGroupVertices([]string{"v-1", "v-missing"}, GroupValues); err != ErrVertexNotExists {
+		t.Fatalf("expected ErrVertexNotExists, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package golang_dag
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// DOTOptions configures Graph.ExportDOT.
+type DOTOptions[T any] struct {
+	// NodeLabel returns the label to render for a vertex. If nil, the
+	// vertex's Id is used.
+	NodeLabel func(v *Vertex[T]) string
+	// EdgeAttrs returns Graphviz attributes (without the surrounding
+	// brackets, e.g. `color=red style=dashed`) for the edge from "from"
+	// to "to". If nil, or it returns "", no attributes are emitted.
+	EdgeAttrs func(from, to *Vertex[T]) string
+	// Cluster, if set, groups vertexes into Graphviz subgraph clusters
+	// keyed by its return value. Vertexes for which it returns "" are
+	// rendered outside any cluster.
+	Cluster func(v *Vertex[T]) string
+}
+
+// ExportDOT writes dag to w as a Graphviz "digraph", suitable for piping
+// into `dot -Tsvg` or similar.
+func (dag *Graph[T]) ExportDOT(w io.Writer, opts DOTOptions[T]) error {
+	label := opts.NodeLabel
+	if label == nil {
+		label = func(v *Vertex[T]) string { return v.Id }
+	}
+
+	if _, err := io.WriteString(w, "digraph dag {\n"); err != nil {
+		return err
+	}
+
+	ids := dag.sortedVertexIds()
+	if err := dag.writeDOTNodes(w, ids, label, opts.Cluster); err != nil {
+		return err
+	}
+	if err := dag.writeDOTEdges(w, ids, opts.EdgeAttrs); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "}\n")
+	return err
+}
+
+func (dag *Graph[T]) writeDOTNodes(w io.Writer, ids []string, label func(v *Vertex[T]) string, cluster func(v *Vertex[T]) string) error {
+	if cluster == nil {
+		for _, id := range ids {
+			if err := writeDOTNode(w, "  ", id, label(dag.Vertexes[id])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	clusters := make(map[string][]string)
+	var unclustered []string
+	for _, id := range ids {
+		key := cluster(dag.Vertexes[id])
+		if key == "" {
+			unclustered = append(unclustered, id)
+			continue
+		}
+		clusters[key] = append(clusters[key], id)
+	}
+
+	keys := make([]string, 0, len(clusters))
+	for key := range clusters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(w, "  subgraph %q {\n    label=%q;\n", "cluster_"+key, key); err != nil {
+			return err
+		}
+		for _, id := range clusters[key] {
+			if err := writeDOTNode(w, "    ", id, label(dag.Vertexes[id])); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, "  }\n"); err != nil {
+			return err
+		}
+	}
+	for _, id := range unclustered {
+		if err := writeDOTNode(w, "  ", id, label(dag.Vertexes[id])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (dag *Graph[T]) writeDOTEdges(w io.Writer, ids []string, edgeAttrs func(from, to *Vertex[T]) string) error {
+	for _, id := range ids {
+		v := dag.Vertexes[id]
+		children := make([]string, 0, len(v.Children))
+		for _, c := range v.Children {
+			children = append(children, c.Id)
+		}
+		sort.Strings(children)
+
+		for _, cId := range children {
+			attrs := ""
+			if edgeAttrs != nil {
+				attrs = edgeAttrs(v, dag.Vertexes[cId])
+			}
+			if attrs == "" {
+				if _, err := fmt.Fprintf(w, "  %q -> %q;\n", v.Id, cId); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "  %q -> %q [%s];\n", v.Id, cId, attrs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeDOTNode(w io.Writer, indent, id, label string) error {
+	_, err := fmt.Fprintf(w, "%s%q [label=%q];\n", indent, id, label)
+	return err
+}
@@ -0,0 +1,55 @@
+package golang_dag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDAG_ExportDOT(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", "build")
+	dag.AddVertex("v-2", "test")
+	dag.AddEdge("v-1", "v-2")
+
+	var buf strings.Builder
+	if err := dag.ExportDOT(&buf, DOTOptions[interface{}]{
+		NodeLabel: func(v *vertex) string { return v.Value.(string) },
+	}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "digraph dag {\n") || !strings.HasSuffix(out, "}\n") {
+		t.Fatalf("not a valid digraph: %q", out)
+	}
+	if !strings.Contains(out, `"v-1" [label="build"];`) {
+		t.Fatalf("missing node label: %q", out)
+	}
+	if !strings.Contains(out, `"v-1" -> "v-2";`) {
+		t.Fatalf("missing edge: %q", out)
+	}
+}
+
+func TestDAG_ExportDOT_ClusterAndEdgeAttrs(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", "a")
+	dag.AddVertex("v-2", "b")
+	dag.AddEdge("v-1", "v-2")
+
+	var buf strings.Builder
+	err := dag.ExportDOT(&buf, DOTOptions[interface{}]{
+		Cluster:   func(v *vertex) string { return "group" },
+		EdgeAttrs: func(from, to *vertex) string { return "color=red" },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `subgraph "cluster_group"`) {
+		t.Fatalf("missing cluster subgraph: %q", out)
+	}
+	if !strings.Contains(out, `"v-1" -> "v-2" [color=red];`) {
+		t.Fatalf("missing styled edge: %q", out)
+	}
+}
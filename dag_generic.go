@@ -0,0 +1,36 @@
+package golang_dag
+
+// Map returns the result of applying fn to every vertex, visited in
+// topological order.
+func (dag *Graph[T]) Map(fn func(v *Vertex[T]) T) []T {
+	sorted := dag.TopologicalSort()
+	out := make([]T, len(sorted))
+	for i, v := range sorted {
+		out[i] = fn(v)
+	}
+	return out
+}
+
+// Filter returns the vertexes, in topological order, for which fn returns
+// true.
+func (dag *Graph[T]) Filter(fn func(v *Vertex[T]) bool) []*Vertex[T] {
+	sorted := dag.TopologicalSort()
+	out := make([]*Vertex[T], 0, len(sorted))
+	for _, v := range sorted {
+		if fn(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Fold reduces the graph to a single value by visiting every vertex in
+// topological order and combining it into the running accumulator (seeded
+// with seed) via fn.
+func (dag *Graph[T]) Fold(seed T, fn func(acc T, v *Vertex[T]) T) T {
+	acc := seed
+	for _, v := range dag.TopologicalSort() {
+		acc = fn(acc, v)
+	}
+	return acc
+}
@@ -0,0 +1,44 @@
+package golang_dag
+
+import "testing"
+
+func TestGraph_Typed(t *testing.T) {
+	g := NewGraph[int]()
+	g.AddVertex("v-1", 1)
+	g.AddVertex("v-2", 2)
+	g.AddVertex("v-3", 3)
+	g.AddEdge("v-1", "v-2")
+	g.AddEdge("v-2", "v-3")
+
+	sorted := g.TopologicalSort()
+	if len(sorted) != 3 {
+		t.Fatalf("wrong vertex count %d", len(sorted))
+	}
+	if sorted[0].Value != 1 || sorted[0].Value+sorted[1].Value+sorted[2].Value != 6 {
+		t.Fatalf("unexpected typed values %v %v %v", sorted[0].Value, sorted[1].Value, sorted[2].Value)
+	}
+
+	doubled := g.Map(func(v *Vertex[int]) int { return v.Value * 2 })
+	if doubled[0] != 2 || doubled[1] != 4 || doubled[2] != 6 {
+		t.Fatalf("wrong Map result %v", doubled)
+	}
+
+	even := g.Filter(func(v *Vertex[int]) bool { return v.Value%2 == 0 })
+	if len(even) != 1 || even[0].Id != "v-2" {
+		t.Fatalf("wrong Filter result %v", even)
+	}
+
+	sum := g.Fold(0, func(acc int, v *Vertex[int]) int { return acc + v.Value })
+	if sum != 6 {
+		t.Fatalf("wrong Fold result %d", sum)
+	}
+}
+
+func TestDAG_IsGraphOfInterface(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", "hello")
+	var v *Vertex[interface{}] = dag.GetVertex("v-1")
+	if v.Value != "hello" {
+		t.Fatalf("expected DAG's GetVertex to return *Vertex[interface{}], got %v", v.Value)
+	}
+}
@@ -0,0 +1,98 @@
+package golang_dag
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+type jsonVertex struct {
+	Id    string          `json:"id"`
+	Value json.RawMessage `json:"value"`
+}
+
+type jsonEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type jsonDAG struct {
+	Vertexes []jsonVertex `json:"vertexes"`
+	Edges    []jsonEdge   `json:"edges"`
+}
+
+// MarshalJSON serializes dag to the stable schema
+// {"vertexes":[{"id","value"}...], "edges":[{"from","to"}...]}.
+func (dag *Graph[T]) MarshalJSON() ([]byte, error) {
+	ids := dag.sortedVertexIds()
+	out := jsonDAG{Vertexes: make([]jsonVertex, 0, len(ids)), Edges: make([]jsonEdge, 0)}
+
+	for _, id := range ids {
+		v := dag.Vertexes[id]
+		raw, err := dag.encodeValue(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		out.Vertexes = append(out.Vertexes, jsonVertex{Id: id, Value: raw})
+
+		children := make([]string, 0, len(v.Children))
+		for _, c := range v.Children {
+			children = append(children, c.Id)
+		}
+		sort.Strings(children)
+		for _, cId := range children {
+			out.Edges = append(out.Edges, jsonEdge{From: id, To: cId})
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON replaces dag's contents with the graph encoded in data, in
+// the schema produced by MarshalJSON. If data does not parse into that
+// schema, dag is left unchanged; otherwise any vertexes and edges already
+// in dag are discarded before decoding, even if decoding then fails partway.
+func (dag *Graph[T]) UnmarshalJSON(data []byte) error {
+	var in jsonDAG
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	dag.Vertexes = make(map[string]*Vertex[T])
+	for _, v := range in.Vertexes {
+		value, err := dag.decodeValue(v.Value)
+		if err != nil {
+			return err
+		}
+		if err := dag.AddVertex(v.Id, value); err != nil {
+			return err
+		}
+	}
+	for _, e := range in.Edges {
+		if err := dag.AddEdge(e.From, e.To); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (dag *Graph[T]) encodeValue(value T) (json.RawMessage, error) {
+	raw, err := json.Marshal(value)
+	if err == nil {
+		return raw, nil
+	}
+	if dag.EncodeValue != nil {
+		return dag.EncodeValue(value)
+	}
+	return nil, err
+}
+
+func (dag *Graph[T]) decodeValue(raw json.RawMessage) (T, error) {
+	if dag.DecodeValue != nil {
+		return dag.DecodeValue(raw)
+	}
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return value, err
+	}
+	return value, nil
+}
@@ -0,0 +1,130 @@
+package golang_dag
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDAG_JSON_RoundTrip(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", "one")
+	dag.AddVertex("v-2", "two")
+	dag.AddVertex("v-3", "three")
+	dag.AddEdge("v-1", "v-2")
+	dag.AddEdge("v-1", "v-3")
+
+	data, err := json.Marshal(dag)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	out := NewDAG()
+	if err := json.Unmarshal(data, out); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if !dag.IsEqual(out) {
+		t.Fatalf("round-tripped dag differs: %s", data)
+	}
+	if out.GetVertex("v-1").Value != "one" {
+		t.Fatalf("expected v-1 value to round-trip, got %v", out.GetVertex("v-1").Value)
+	}
+}
+
+func TestDAG_JSON_MarshalEmitsEmptyEdgesArray(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", "one")
+
+	data, err := json.Marshal(dag)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if string(raw["edges"]) != "[]" {
+		t.Fatalf(`expected "edges":[], got %s`, raw["edges"])
+	}
+}
+
+func TestDAG_JSON_UnmarshalReplacesExistingContents(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", "one")
+	data, err := json.Marshal(dag)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	out := NewDAG()
+	out.AddVertex("stale", "stale")
+	if err := json.Unmarshal(data, out); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if out.GetVertex("stale") != nil {
+		t.Fatalf("expected stale vertex to be discarded by UnmarshalJSON")
+	}
+	if out.GetVertex("v-1") == nil {
+		t.Fatalf("expected v-1 to be present after UnmarshalJSON")
+	}
+	if len(out.Vertexes) != 1 {
+		t.Fatalf("expected exactly 1 vertex after UnmarshalJSON, got %d", len(out.Vertexes))
+	}
+}
+
+func TestDAG_JSON_Codec(t *testing.T) {
+	// complex128 is not representable in JSON, so json.Marshal/Unmarshal
+	// rejects it on its own and the registered codec must take over.
+	type point = complex128
+
+	dag := NewGraph[point]()
+	dag.EncodeValue = func(value point) (json.RawMessage, error) {
+		return json.Marshal(map[string]float64{"re": real(value), "im": imag(value)})
+	}
+	dag.DecodeValue = func(raw json.RawMessage) (point, error) {
+		var m map[string]float64
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return 0, err
+		}
+		return complex(m["re"], m["im"]), nil
+	}
+	dag.AddVertex("v-1", point(complex(1, 2)))
+
+	data, err := json.Marshal(dag)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	out := NewGraph[point]()
+	out.DecodeValue = dag.DecodeValue
+	if err := json.Unmarshal(data, out); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if out.GetVertex("v-1").Value != point(complex(1, 2)) {
+		t.Fatalf("expected value to round-trip through codec, got %v", out.GetVertex("v-1").Value)
+	}
+}
+
+func TestDAG_JSON_Codec_PerInstanceIsolation(t *testing.T) {
+	// A codec registered on one Graph[T] must not leak to another Graph[T]
+	// of the same value type.
+	type point = complex128
+
+	withCodec := NewGraph[point]()
+	withCodec.EncodeValue = func(value point) (json.RawMessage, error) {
+		return json.Marshal(map[string]float64{"re": real(value), "im": imag(value)})
+	}
+	withCodec.AddVertex("v-1", point(complex(1, 2)))
+
+	withoutCodec := NewGraph[point]()
+	withoutCodec.AddVertex("v-1", point(complex(1, 2)))
+
+	if _, err := json.Marshal(withoutCodec); err == nil {
+		t.Fatalf("expected marshal without a registered codec to fail for complex128")
+	}
+	if _, err := json.Marshal(withCodec); err != nil {
+		t.Fatalf("unexpected error marshaling with a registered codec: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package golang_dag
+
+// Ancestors returns every vertex reachable by following Parents from the
+// vertex identified by id, keyed by id. It returns ErrVertexNotExists if
+// id is not in the graph.
+func (dag *Graph[T]) Ancestors(id string) (map[string]*Vertex[T], error) {
+	v, ok := dag.Vertexes[id]
+	if !ok {
+		return nil, ErrVertexNotExists
+	}
+	found := map[string]*Vertex[T]{}
+	dag.ancestors(found, v)
+	return found, nil
+}
+
+func (dag *Graph[T]) ancestors(found map[string]*Vertex[T], v *Vertex[T]) {
+	for _, p := range v.Parents {
+		if _, ok := found[p.Id]; !ok {
+			found[p.Id] = p
+			dag.ancestors(found, p)
+		}
+	}
+}
+
+// Descendants returns every vertex reachable by following Children from
+// the vertex identified by id, keyed by id. It returns ErrVertexNotExists
+// if id is not in the graph.
+func (dag *Graph[T]) Descendants(id string) (map[string]*Vertex[T], error) {
+	v, ok := dag.Vertexes[id]
+	if !ok {
+		return nil, ErrVertexNotExists
+	}
+	found := map[string]*Vertex[T]{}
+	dag.descendants(found, v)
+	return found, nil
+}
+
+func (dag *Graph[T]) descendants(found map[string]*Vertex[T], v *Vertex[T]) {
+	for _, c := range v.Children {
+		if _, ok := found[c.Id]; !ok {
+			found[c.Id] = c
+			dag.descendants(found, c)
+		}
+	}
+}
+
+// Roots returns every vertex with no parents, in sorted id order.
+func (dag *Graph[T]) Roots() []*Vertex[T] {
+	roots := make([]*Vertex[T], 0)
+	for _, id := range dag.sortedVertexIds() {
+		if v := dag.Vertexes[id]; len(v.Parents) == 0 {
+			roots = append(roots, v)
+		}
+	}
+	return roots
+}
+
+// Leaves returns every vertex with no children, in sorted id order.
+func (dag *Graph[T]) Leaves() []*Vertex[T] {
+	leaves := make([]*Vertex[T], 0)
+	for _, id := range dag.sortedVertexIds() {
+		if v := dag.Vertexes[id]; len(v.Children) == 0 {
+			leaves = append(leaves, v)
+		}
+	}
+	return leaves
+}
+
+// TransitiveReduction removes every edge (u, w) for which some other path
+// u -> v -> ... -> w already exists, leaving the graph's reachability
+// unchanged.
+func (dag *Graph[T]) TransitiveReduction() {
+	for _, id := range dag.sortedVertexIds() {
+		u, ok := dag.Vertexes[id]
+		if !ok {
+			continue
+		}
+
+		children := make([]*Vertex[T], len(u.Children))
+		copy(children, u.Children)
+
+		// indirect holds every vertex reachable from u through some
+		// child's subtree. A direct child that shows up here is also
+		// reachable via a longer path, so its direct edge is redundant.
+		indirect := map[string]bool{}
+		for _, c := range children {
+			dag.dfs(indirect, c.Id)
+		}
+
+		for _, c := range children {
+			if indirect[c.Id] {
+				dag.RemoveEdge(u.Id, c.Id)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package golang_dag
+
+import "testing"
+
+func TestDAG_AncestorsAndDescendants(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", 1)
+	dag.AddVertex("v-2", 2)
+	dag.AddVertex("v-3", 3)
+	dag.AddVertex("v-4", 4)
+	dag.AddEdge("v-1", "v-2")
+	dag.AddEdge("v-2", "v-3")
+	dag.AddEdge("v-1", "v-4")
+
+	ancestors, err := dag.Ancestors("v-3")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(ancestors) != 2 || ancestors["v-1"] == nil || ancestors["v-2"] == nil {
+		t.Fatalf("wrong ancestors of v-3: %v", ancestors)
+	}
+
+	descendants, err := dag.Descendants("v-1")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(descendants) != 3 {
+		t.Fatalf("wrong descendants of v-1: %v", descendants)
+	}
+
+	if _, err := dag.Ancestors("missing"); err != ErrVertexNotExists {
+		t.Fatalf("expected ErrVertexNotExists, got %v", err)
+	}
+}
+
+func TestDAG_RootsAndLeaves(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", 1)
+	dag.AddVertex("v-2", 2)
+	dag.AddVertex("v-3", 3)
+	dag.AddEdge("v-1", "v-2")
+	dag.AddEdge("v-2", "v-3")
+
+	roots := dag.Roots()
+	if len(roots) != 1 || roots[0].Id != "v-1" {
+		t.Fatalf("wrong roots: %v", roots)
+	}
+
+	leaves := dag.Leaves()
+	if len(leaves) != 1 || leaves[0].Id != "v-3" {
+		t.Fatalf("wrong leaves: %v", leaves)
+	}
+}
+
+func TestDAG_TransitiveReduction(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", 1)
+	dag.AddVertex("v-2", 2)
+	dag.AddVertex("v-3", 3)
+	dag.AddEdge("v-1", "v-2")
+	dag.AddEdge("v-2", "v-3")
+	dag.AddEdge("v-1", "v-3") // redundant: v-1 already reaches v-3 via v-2
+
+	dag.TransitiveReduction()
+
+	exists, err := dag.EdgeExists("v-1", "v-3")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if exists {
+		t.Fatalf("expected redundant edge v-1 -> v-3 to be removed")
+	}
+	exists, err = dag.EdgeExists("v-1", "v-2")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !exists {
+		t.Fatalf("expected edge v-1 -> v-2 to remain")
+	}
+	if !dag.DepthFirstSearch("v-1", "v-3") {
+		t.Fatalf("expected v-3 to still be reachable from v-1")
+	}
+}
@@ -0,0 +1,144 @@
+package golang_dag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrSkipped is the error recorded for a vertex whose fn was never invoked
+// because one of its ancestors (or, for WalkReverse, descendants) failed.
+var ErrSkipped = errors.New("dag: vertex skipped due to dependency failure")
+
+// WalkError aggregates the per-vertex errors of a Walk or WalkReverse call,
+// keyed by vertex id: the errors returned by fn, plus ErrSkipped for every
+// vertex whose fn was never invoked.
+type WalkError struct {
+	Errors map[string]error
+}
+
+func (e *WalkError) Error() string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", id, e.Errors[id]))
+	}
+	return fmt.Sprintf("dag: walk failed: %s", strings.Join(msgs, "; "))
+}
+
+// Walk executes fn for every vertex in dependency order, using up to
+// concurrency worker goroutines. A vertex's fn is only called once all of
+// its parents have completed successfully. If fn returns an error for a
+// vertex, ctx is cancelled, all of that vertex's descendants are skipped
+// (their error is ErrSkipped), and any in-flight or already-queued work is
+// allowed to drain before Walk returns a *WalkError aggregating every
+// failure and skip. Walk returns nil if every vertex's fn succeeded.
+func (dag *Graph[T]) Walk(ctx context.Context, concurrency int, fn func(ctx context.Context, v *Vertex[T]) error) error {
+	return dag.walk(ctx, concurrency, fn, false)
+}
+
+// WalkReverse is like Walk, but traverses the graph children-first: a
+// vertex's fn only runs once all of its children have completed
+// successfully, and a failure skips the vertex's ancestors instead of its
+// descendants.
+func (dag *Graph[T]) WalkReverse(ctx context.Context, concurrency int, fn func(ctx context.Context, v *Vertex[T]) error) error {
+	return dag.walk(ctx, concurrency, fn, true)
+}
+
+func (dag *Graph[T]) walk(ctx context.Context, concurrency int, fn func(ctx context.Context, v *Vertex[T]) error, reverse bool) error {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	total := len(dag.Vertexes)
+	if total == 0 {
+		return nil
+	}
+
+	upstream := func(v *Vertex[T]) []*Vertex[T] {
+		if reverse {
+			return v.Children
+		}
+		return v.Parents
+	}
+	downstream := func(v *Vertex[T]) []*Vertex[T] {
+		if reverse {
+			return v.Parents
+		}
+		return v.Children
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ready := make(chan *Vertex[T], total)
+	indegree := make(map[string]int, total)
+	for _, v := range dag.Vertexes {
+		indegree[v.Id] = len(upstream(v))
+		if indegree[v.Id] == 0 {
+			ready <- v
+		}
+	}
+
+	var mu sync.Mutex
+	errs := make(map[string]error)
+	tainted := make(map[string]bool)
+	remaining := total
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range ready {
+				mu.Lock()
+				skip := tainted[v.Id]
+				mu.Unlock()
+
+				var err error
+				if skip {
+					err = ErrSkipped
+				} else {
+					err = fn(ctx, v)
+				}
+
+				mu.Lock()
+				if err != nil {
+					errs[v.Id] = err
+					if err != ErrSkipped {
+						cancel()
+					}
+				}
+				for _, n := range downstream(v) {
+					if err != nil {
+						tainted[n.Id] = true
+					}
+					indegree[n.Id]--
+					if indegree[n.Id] == 0 {
+						ready <- n
+					}
+				}
+				remaining--
+				if remaining == 0 {
+					close(ready)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return &WalkError{Errors: errs}
+}
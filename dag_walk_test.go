@@ -0,0 +1,143 @@
+package golang_dag
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestDAG_Walk(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", 1)
+	dag.AddVertex("v-2", 2)
+	dag.AddVertex("v-3", 3)
+	dag.AddVertex("v-4", 4)
+	dag.AddEdge("v-1", "v-2")
+	dag.AddEdge("v-1", "v-3")
+	dag.AddEdge("v-2", "v-4")
+	dag.AddEdge("v-3", "v-4")
+
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	err := dag.Walk(context.Background(), 2, func(ctx context.Context, v *vertex) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, p := range v.Parents {
+			if !seen[p.Id] {
+				t.Fatalf("vertex %s ran before parent %s", v.Id, p.Id)
+			}
+		}
+		seen[v.Id] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(seen) != 4 {
+		t.Fatalf("expected 4 vertexes visited, got %d", len(seen))
+	}
+}
+
+func TestDAG_Walk_SkipsDescendantsOnError(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", 1)
+	dag.AddVertex("v-2", 2)
+	dag.AddVertex("v-3", 3)
+	dag.AddEdge("v-1", "v-2")
+	dag.AddEdge("v-2", "v-3")
+
+	boom := errors.New("boom")
+
+	var mu sync.Mutex
+	results := map[string]error{}
+	err := dag.Walk(context.Background(), 1, func(ctx context.Context, v *vertex) error {
+		mu.Lock()
+		defer mu.Unlock()
+		var err error
+		if v.Id == "v-1" {
+			err = boom
+		}
+		results[v.Id] = err
+		return err
+	})
+
+	var walkErr *WalkError
+	if !errors.As(err, &walkErr) {
+		t.Fatalf("expected *WalkError, got %v", err)
+	}
+	if walkErr.Errors["v-1"] != boom {
+		t.Fatalf("expected v-1 to fail with boom, got %v", walkErr.Errors["v-1"])
+	}
+	if !errors.Is(walkErr.Errors["v-2"], ErrSkipped) || !errors.Is(walkErr.Errors["v-3"], ErrSkipped) {
+		t.Fatalf("expected v-2 and v-3 to be skipped, got %v", walkErr.Errors)
+	}
+}
+
+func TestDAG_Walk_IndependentBranchUnaffectedByError(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("a1", "a1")
+	dag.AddVertex("a2", "a2")
+	dag.AddVertex("b1", "b1")
+	dag.AddVertex("b2", "b2")
+	dag.AddEdge("a1", "a2")
+	dag.AddEdge("b1", "b2")
+
+	boom := errors.New("boom")
+
+	var mu sync.Mutex
+	results := map[string]error{}
+	err := dag.Walk(context.Background(), 1, func(ctx context.Context, v *vertex) error {
+		mu.Lock()
+		defer mu.Unlock()
+		var err error
+		if v.Id == "a1" {
+			err = boom
+		}
+		results[v.Id] = err
+		return err
+	})
+
+	var walkErr *WalkError
+	if !errors.As(err, &walkErr) {
+		t.Fatalf("expected *WalkError, got %v", err)
+	}
+	if walkErr.Errors["a1"] != boom {
+		t.Fatalf("expected a1 to fail with boom, got %v", walkErr.Errors["a1"])
+	}
+	if !errors.Is(walkErr.Errors["a2"], ErrSkipped) {
+		t.Fatalf("expected a2 to be skipped, got %v", walkErr.Errors["a2"])
+	}
+	if _, failed := walkErr.Errors["b1"]; failed {
+		t.Fatalf("expected b1 to succeed, got %v", walkErr.Errors["b1"])
+	}
+	if _, failed := walkErr.Errors["b2"]; failed {
+		t.Fatalf("expected b2 to succeed since its only parent b1 succeeded, got %v", walkErr.Errors["b2"])
+	}
+}
+
+func TestDAG_WalkReverse(t *testing.T) {
+	dag := NewDAG()
+	dag.AddVertex("v-1", 1)
+	dag.AddVertex("v-2", 2)
+	dag.AddVertex("v-3", 3)
+	dag.AddEdge("v-1", "v-2")
+	dag.AddEdge("v-2", "v-3")
+
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	err := dag.WalkReverse(context.Background(), 2, func(ctx context.Context, v *vertex) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, c := range v.Children {
+			if !seen[c.Id] {
+				t.Fatalf("vertex %s ran before child %s", v.Id, c.Id)
+			}
+		}
+		seen[v.Id] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}